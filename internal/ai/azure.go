@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultAzureAPIVersion = "2024-02-01"
+
+// AzureOpenAIProvider はAzure OpenAI Serviceを使用したプロバイダー
+// Azure OpenAIはデプロイメント単位でURLが分かれており、認証も`api-key`ヘッダーで行う点がOpenAIと異なる
+type AzureOpenAIProvider struct {
+	apiKey         string
+	endpoint       string
+	deploymentName string
+	apiVersion     string
+}
+
+// NewAzureOpenAIProvider は新しいAzureOpenAIProviderを作成する
+func NewAzureOpenAIProvider(apiKey, endpoint, deploymentName, apiVersion string) (*AzureOpenAIProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+	if deploymentName == "" {
+		return nil, fmt.Errorf("deployment name is required")
+	}
+	if apiVersion == "" {
+		apiVersion = defaultAzureAPIVersion
+	}
+
+	return &AzureOpenAIProvider{
+		apiKey:         apiKey,
+		endpoint:       strings.TrimRight(endpoint, "/"),
+		deploymentName: deploymentName,
+		apiVersion:     apiVersion,
+	}, nil
+}
+
+// Name はプロバイダー名を返す
+func (p *AzureOpenAIProvider) Name() string {
+	return "azure-openai"
+}
+
+// url はデプロイメント単位のチャットコンプリーションAPIのURLを組み立てる
+func (p *AzureOpenAIProvider) url() string {
+	return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s", p.endpoint, p.deploymentName, p.apiVersion)
+}
+
+// Verify はSPECとコードの一致度を検証する
+func (p *AzureOpenAIProvider) Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error) {
+	prompt := buildVerificationPrompt(specContent, codeContents)
+
+	req := openAIRequest{
+		Messages: []openAIChatMessage{
+			{Role: "user", Content: prompt},
+		},
+	}
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.url(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("api-key", p.apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	captureRequestID(ctx, resp.Header.Get("apim-request-id"))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var oaResp openAIResponse
+	if err := json.Unmarshal(body, &oaResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if oaResp.Error != nil {
+		return nil, fmt.Errorf("API error: %s", oaResp.Error.Message)
+	}
+
+	if len(oaResp.Choices) == 0 {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	return parseVerificationResult(oaResp.Choices[0].Message.Content)
+}
+
+func init() {
+	Register("azure-openai", func(cfg ProviderConfig) (Provider, error) {
+		return NewAzureOpenAIProvider(cfg.APIKey, cfg.Endpoint, cfg.DeploymentName, cfg.APIVersion)
+	})
+}
@@ -0,0 +1,49 @@
+package ai
+
+import "testing"
+
+func TestNewAzureOpenAIProviderValidation(t *testing.T) {
+	cases := []struct {
+		name           string
+		apiKey         string
+		endpoint       string
+		deploymentName string
+		wantErr        bool
+	}{
+		{"missing API key", "", "https://example.openai.azure.com", "gpt-4o", true},
+		{"missing endpoint", "key", "", "gpt-4o", true},
+		{"missing deployment name", "key", "https://example.openai.azure.com", "", true},
+		{"all required fields set", "key", "https://example.openai.azure.com", "gpt-4o", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAzureOpenAIProvider(tt.apiKey, tt.endpoint, tt.deploymentName, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewAzureOpenAIProviderDefaultsAPIVersion(t *testing.T) {
+	p, err := NewAzureOpenAIProvider("key", "https://example.openai.azure.com", "gpt-4o", "")
+	if err != nil {
+		t.Fatalf("NewAzureOpenAIProvider() error = %v", err)
+	}
+	if p.apiVersion != defaultAzureAPIVersion {
+		t.Errorf("apiVersion = %q, want %q", p.apiVersion, defaultAzureAPIVersion)
+	}
+}
+
+func TestAzureOpenAIProviderURLTrimsTrailingSlashAndBuildsQuery(t *testing.T) {
+	p, err := NewAzureOpenAIProvider("key", "https://example.openai.azure.com/", "gpt-4o", "2024-06-01")
+	if err != nil {
+		t.Fatalf("NewAzureOpenAIProvider() error = %v", err)
+	}
+
+	want := "https://example.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version=2024-06-01"
+	if got := p.url(); got != want {
+		t.Errorf("url() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	bedrockServiceName  = "bedrock"
+	defaultBedrockModel = "anthropic.claude-3-5-sonnet-20241022-v2:0"
+)
+
+// BedrockProvider はAWS BedrockのInvokeModel APIを使用したプロバイダー
+// `anthropic.claude-*`と`amazon.nova-*`の両モデルファミリーに対応し、
+// リクエスト/レスポンスの形式が異なる部分のみモデルIDで分岐する
+type BedrockProvider struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	modelID         string
+}
+
+// NewBedrockProvider は新しいBedrockProviderを作成する
+func NewBedrockProvider(region, accessKeyID, secretAccessKey, sessionToken, modelID string) (*BedrockProvider, error) {
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("AWS credentials are required")
+	}
+	if modelID == "" {
+		modelID = defaultBedrockModel
+	}
+
+	return &BedrockProvider{
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		sessionToken:    sessionToken,
+		modelID:         modelID,
+	}, nil
+}
+
+// Name はプロバイダー名を返す
+func (p *BedrockProvider) Name() string {
+	return "bedrock"
+}
+
+// endpoint はモデルIDに対応するInvokeModelのエンドポイントURLを組み立てる
+func (p *BedrockProvider) endpoint() string {
+	return fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com/model/%s/invoke", p.region, url.PathEscape(p.modelID))
+}
+
+// Verify はSPECとコードの一致度を検証する
+func (p *BedrockProvider) Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error) {
+	prompt := buildVerificationPrompt(specContent, codeContents)
+
+	reqBody, err := p.buildRequestBody(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.endpoint(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	if err := signAWSRequestV4(httpReq, reqBody, p.accessKeyID, p.secretAccessKey, p.sessionToken, p.region, bedrockServiceName, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	captureRequestID(ctx, resp.Header.Get("x-amzn-RequestId"))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	text, err := p.extractResponseText(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVerificationResult(text)
+}
+
+// buildRequestBody はモデルファミリーごとのInvokeModelリクエストボディを組み立てる
+func (p *BedrockProvider) buildRequestBody(prompt string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(p.modelID, "anthropic.claude"):
+		return json.Marshal(map[string]interface{}{
+			"anthropic_version": "bedrock-2023-05-31",
+			"max_tokens":        2000,
+			"messages": []map[string]string{
+				{"role": "user", "content": prompt},
+			},
+		})
+	case strings.HasPrefix(p.modelID, "amazon.nova"):
+		return json.Marshal(map[string]interface{}{
+			"messages": []map[string]interface{}{
+				{
+					"role":    "user",
+					"content": []map[string]string{{"text": prompt}},
+				},
+			},
+			"inferenceConfig": map[string]interface{}{"max_new_tokens": 2000},
+		})
+	default:
+		return nil, fmt.Errorf("unsupported bedrock model: %s", p.modelID)
+	}
+}
+
+// extractResponseText はモデルファミリーごとのレスポンス形式からテキスト出力を取り出す
+func (p *BedrockProvider) extractResponseText(body []byte) (string, error) {
+	switch {
+	case strings.HasPrefix(p.modelID, "anthropic.claude"):
+		var resp struct {
+			Content []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", fmt.Errorf("failed to parse response: %w", err)
+		}
+		if len(resp.Content) == 0 {
+			return "", fmt.Errorf("empty response from API")
+		}
+		return resp.Content[0].Text, nil
+
+	case strings.HasPrefix(p.modelID, "amazon.nova"):
+		var resp struct {
+			Output struct {
+				Message struct {
+					Content []struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"message"`
+			} `json:"output"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", fmt.Errorf("failed to parse response: %w", err)
+		}
+		if len(resp.Output.Message.Content) == 0 {
+			return "", fmt.Errorf("empty response from API")
+		}
+		return resp.Output.Message.Content[0].Text, nil
+
+	default:
+		return "", fmt.Errorf("unsupported bedrock model: %s", p.modelID)
+	}
+}
+
+func init() {
+	Register("bedrock", func(cfg ProviderConfig) (Provider, error) {
+		return NewBedrockProvider(cfg.Region, cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken, cfg.Model)
+	})
+}
@@ -0,0 +1,123 @@
+package ai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildRequestBodyClaudeModelFamily(t *testing.T) {
+	p := &BedrockProvider{modelID: "anthropic.claude-3-5-sonnet-20241022-v2:0"}
+
+	body, err := p.buildRequestBody("do the thing")
+	if err != nil {
+		t.Fatalf("buildRequestBody() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if got["anthropic_version"] != "bedrock-2023-05-31" {
+		t.Errorf("anthropic_version = %v, want %v", got["anthropic_version"], "bedrock-2023-05-31")
+	}
+	messages, ok := got["messages"].([]interface{})
+	if !ok || len(messages) != 1 {
+		t.Fatalf("messages = %v, want a single-element array", got["messages"])
+	}
+	msg := messages[0].(map[string]interface{})
+	if msg["content"] != "do the thing" {
+		t.Errorf("messages[0].content = %v, want %q", msg["content"], "do the thing")
+	}
+}
+
+func TestBuildRequestBodyNovaModelFamily(t *testing.T) {
+	p := &BedrockProvider{modelID: "amazon.nova-pro-v1:0"}
+
+	body, err := p.buildRequestBody("do the thing")
+	if err != nil {
+		t.Fatalf("buildRequestBody() error = %v", err)
+	}
+	if !strings.Contains(string(body), `"inferenceConfig"`) {
+		t.Errorf("body = %s, want it to include inferenceConfig for the nova family", body)
+	}
+	if !strings.Contains(string(body), `"text":"do the thing"`) {
+		t.Errorf("body = %s, want the prompt nested under content[].text", body)
+	}
+}
+
+func TestBuildRequestBodyUnsupportedModel(t *testing.T) {
+	p := &BedrockProvider{modelID: "cohere.command-r"}
+	if _, err := p.buildRequestBody("prompt"); err == nil {
+		t.Error("buildRequestBody() with an unsupported model should return an error")
+	}
+}
+
+func TestExtractResponseTextClaudeModelFamily(t *testing.T) {
+	p := &BedrockProvider{modelID: "anthropic.claude-3-5-sonnet-20241022-v2:0"}
+	raw := `{"content":[{"type":"text","text":"the verification result"}]}`
+
+	got, err := p.extractResponseText([]byte(raw))
+	if err != nil {
+		t.Fatalf("extractResponseText() error = %v", err)
+	}
+	if got != "the verification result" {
+		t.Errorf("got %q, want %q", got, "the verification result")
+	}
+}
+
+func TestExtractResponseTextNovaModelFamily(t *testing.T) {
+	p := &BedrockProvider{modelID: "amazon.nova-pro-v1:0"}
+	raw := `{"output":{"message":{"content":[{"text":"the verification result"}]}}}`
+
+	got, err := p.extractResponseText([]byte(raw))
+	if err != nil {
+		t.Fatalf("extractResponseText() error = %v", err)
+	}
+	if got != "the verification result" {
+		t.Errorf("got %q, want %q", got, "the verification result")
+	}
+}
+
+func TestExtractResponseTextEmptyContent(t *testing.T) {
+	p := &BedrockProvider{modelID: "anthropic.claude-3-5-sonnet-20241022-v2:0"}
+	if _, err := p.extractResponseText([]byte(`{"content":[]}`)); err == nil {
+		t.Error("extractResponseText() with empty content should return an error")
+	}
+}
+
+func TestBedrockProviderEndpointEscapesModelID(t *testing.T) {
+	p := &BedrockProvider{region: "us-east-1", modelID: "anthropic.claude-3-5-sonnet-20241022-v2:0"}
+	want := "https://bedrock-runtime.us-east-1.amazonaws.com/model/anthropic.claude-3-5-sonnet-20241022-v2:0/invoke"
+	if got := p.endpoint(); got != want {
+		t.Errorf("endpoint() = %q, want %q", got, want)
+	}
+
+	// スラッシュを含むモデルID（推論プロファイルARNの一部など）は/model/配下の1セグメントとして
+	// パスエスケープされる必要がある
+	p2 := &BedrockProvider{region: "us-east-1", modelID: "provisioned/my-model"}
+	want2 := "https://bedrock-runtime.us-east-1.amazonaws.com/model/provisioned%2Fmy-model/invoke"
+	if got := p2.endpoint(); got != want2 {
+		t.Errorf("endpoint() = %q, want %q", got, want2)
+	}
+}
+
+func TestNewBedrockProviderDefaultsModelID(t *testing.T) {
+	p, err := NewBedrockProvider("us-east-1", "AKID", "secret", "", "")
+	if err != nil {
+		t.Fatalf("NewBedrockProvider() error = %v", err)
+	}
+	if p.modelID != defaultBedrockModel {
+		t.Errorf("modelID = %q, want %q", p.modelID, defaultBedrockModel)
+	}
+}
+
+func TestNewBedrockProviderRequiresCredentials(t *testing.T) {
+	if _, err := NewBedrockProvider("us-east-1", "", "secret", "", ""); err == nil {
+		t.Error("NewBedrockProvider() without an access key should return an error")
+	}
+	if _, err := NewBedrockProvider("", "AKID", "secret", "", ""); err == nil {
+		t.Error("NewBedrockProvider() without a region should return an error")
+	}
+}
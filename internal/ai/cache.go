@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"sync"
+	"time"
+)
+
+// promptTemplateVersion は buildVerificationPrompt の出力形式のバージョン
+// プロンプトの文面を変更した場合はインクリメントし、古いバージョンで作成された
+// キャッシュエントリが新しいプロンプトの結果と混同されないようにする
+const promptTemplateVersion = "v1"
+
+// CacheEntry はキャッシュに保存される検証結果と、その付随情報
+type CacheEntry struct {
+	Result    *VerificationResult
+	RequestID string
+	CreatedAt time.Time
+}
+
+// Cache はSPEC＋コードのハッシュをキーとした検証結果の保存先を表す
+// インメモリ実装（MemoryCache）とディスク実装（DiskCache）を用意している
+type Cache interface {
+	// Get はキーに対応するエントリを返す。存在しないか期限切れの場合はok=false
+	Get(key string) (entry *CacheEntry, ok bool)
+	// Set はキーにエントリを保存する
+	Set(key string, entry CacheEntry) error
+	// Purge はolderThanより古いエントリをすべて削除する
+	Purge(olderThan time.Time) error
+}
+
+// CacheKey はプロバイダー名・モデル名・プロンプトテンプレートのバージョン・SPEC・
+// ソートされたコード内容からSHA-256ハッシュを計算し、キャッシュキーとして返す
+// ファイルの並び順に依存しないよう、コードはパス名でソートしてからハッシュに含める
+// 各フィールドは長さ接頭辞を付けてハッシュに投入し、あるフィールドの内容が
+// 区切り文字列と紛らわしい形を取っていても異なる入力同士が衝突しないようにする
+func CacheKey(providerName, model, specContent string, codeContents map[string]string) string {
+	paths := make([]string, 0, len(codeContents))
+	for path := range codeContents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	writeLengthPrefixed(h, providerName)
+	writeLengthPrefixed(h, model)
+	writeLengthPrefixed(h, promptTemplateVersion)
+	writeLengthPrefixed(h, specContent)
+	for _, path := range paths {
+		writeLengthPrefixed(h, path)
+		writeLengthPrefixed(h, codeContents[path])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeLengthPrefixed はハッシュへ書き込むフィールド同士の境界が曖昧にならないよう、
+// 各フィールドの前にそのバイト長を書き込んでからデータ本体を書き込む
+func writeLengthPrefixed(h hash.Hash, field string) {
+	fmt.Fprintf(h, "%d:", len(field))
+	h.Write([]byte(field))
+}
+
+// MemoryCache はプロセス内のみで有効なCacheの実装
+type MemoryCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache は新しいMemoryCacheを作成する。ttl<=0の場合は無期限にキャッシュする
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:     ttl,
+		entries: map[string]CacheEntry{},
+	}
+}
+
+// Get はキーに対応するエントリを返す
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// Set はキーにエントリを保存する
+func (c *MemoryCache) Set(key string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+	return nil
+}
+
+// Purge はolderThanより古いエントリをすべて削除する
+func (c *MemoryCache) Purge(olderThan time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.CreatedAt.Before(olderThan) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DiskCache はCacheの永続化実装
+// キーごとに1ファイルを $XDG_CACHE_HOME/gh-spec-verify（未設定時は ~/.cache/gh-spec-verify）
+// 以下に書き出す。複数のCLIプロセスが同時に同じキーへ書き込んでも壊れたファイルが
+// 残らないよう、一時ファイルへ書いてからリネームするcontent-addressableストア流の
+// パターンを使い、さらにプロセス内の同時呼び出しに備えてmutexでも保護する
+type DiskCache struct {
+	mu  sync.Mutex
+	dir string
+	ttl time.Duration
+}
+
+// diskCacheRecord はDiskCacheがディスク上に書き出すJSONの形
+type diskCacheRecord struct {
+	Result    *VerificationResult `json:"result"`
+	RequestID string              `json:"requestId"`
+	CreatedAt time.Time           `json:"createdAt"`
+}
+
+// NewDiskCache はdirにキャッシュを保存するDiskCacheを作成する
+// dirが空文字の場合はDefaultCacheDir()を使用する。ttl<=0の場合は無期限にキャッシュする
+func NewDiskCache(dir string, ttl time.Duration) (*DiskCache, error) {
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	return &DiskCache{dir: dir, ttl: ttl}, nil
+}
+
+// DefaultCacheDir は $XDG_CACHE_HOME/gh-spec-verify（未設定時は ~/.cache/gh-spec-verify）を返す
+func DefaultCacheDir() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "gh-spec-verify")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".cache", "gh-spec-verify")
+	}
+	return filepath.Join(os.TempDir(), "gh-spec-verify")
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Get はキーに対応するエントリを返す
+func (c *DiskCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var record diskCacheRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false
+	}
+
+	if c.ttl > 0 && time.Since(record.CreatedAt) > c.ttl {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return &CacheEntry{Result: record.Result, RequestID: record.RequestID, CreatedAt: record.CreatedAt}, true
+}
+
+// Set はキーにエントリを保存する。書き込みは一時ファイル経由のリネームでアトミックに行う
+func (c *DiskCache) Set(key string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(diskCacheRecord{
+		Result:    entry.Result,
+		RequestID: entry.RequestID,
+		CreatedAt: entry.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to commit cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Purge はolderThanより古いエントリをすべて削除する
+func (c *DiskCache) Purge(olderThan time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cache dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(c.dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var record diskCacheRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			continue
+		}
+
+		if record.CreatedAt.Before(olderThan) {
+			os.Remove(path)
+		}
+	}
+
+	return nil
+}
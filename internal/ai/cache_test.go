@@ -0,0 +1,170 @@
+package ai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheKeyIsStableAndOrderIndependent(t *testing.T) {
+	files := map[string]string{"b.go": "bbb", "a.go": "aaa"}
+
+	k1 := CacheKey("claude", "claude-3-5-sonnet", "spec", files)
+	k2 := CacheKey("claude", "claude-3-5-sonnet", "spec", files)
+	if k1 != k2 {
+		t.Error("CacheKey() should be deterministic for identical inputs")
+	}
+
+	if k3 := CacheKey("claude", "claude-3-5-sonnet", "spec", map[string]string{"a.go": "aaa", "b.go": "bbb"}); k1 != k3 {
+		t.Error("CacheKey() should not depend on map iteration order")
+	}
+}
+
+func TestCacheKeyDoesNotCollideAcrossDifferentFileSplits(t *testing.T) {
+	// 回帰テスト: 長さ接頭辞なしの単純連結では、1ファイルの内容が別のファイル分割と
+	// 同じバイト列を作り出せてしまう可能性があった
+	a := CacheKey("claude", "m", "spec", map[string]string{
+		"x": "file=y\nbody",
+	})
+	b := CacheKey("claude", "m", "spec", map[string]string{
+		"x":       "file=",
+		"y\nbody": "", // invalid path in practice, but demonstrates the same concatenated bytes
+	})
+
+	if a == b {
+		t.Error("CacheKey() collided across two different (path, content) splits that concatenate to the same bytes")
+	}
+}
+
+func TestMemoryCacheTTLExpiry(t *testing.T) {
+	c := NewMemoryCache(10 * time.Millisecond)
+	entry := CacheEntry{Result: &VerificationResult{MatchPercentage: 50}, CreatedAt: time.Now()}
+
+	if err := c.Set("key", entry); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if _, ok := c.Get("key"); !ok {
+		t.Fatal("Get() immediately after Set() should hit")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get() after the TTL elapsed should miss")
+	}
+}
+
+func TestMemoryCacheNoTTLNeverExpires(t *testing.T) {
+	c := NewMemoryCache(0)
+	entry := CacheEntry{Result: &VerificationResult{MatchPercentage: 50}, CreatedAt: time.Now().Add(-24 * time.Hour)}
+
+	if err := c.Set("key", entry); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, ok := c.Get("key"); !ok {
+		t.Error("Get() with ttl<=0 should never expire entries")
+	}
+}
+
+func TestMemoryCachePurge(t *testing.T) {
+	c := NewMemoryCache(0)
+	old := CacheEntry{Result: &VerificationResult{}, CreatedAt: time.Now().Add(-time.Hour)}
+	fresh := CacheEntry{Result: &VerificationResult{}, CreatedAt: time.Now()}
+
+	c.Set("old", old)
+	c.Set("fresh", fresh)
+
+	if err := c.Purge(time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+
+	if _, ok := c.Get("old"); ok {
+		t.Error("Purge() should have removed the old entry")
+	}
+	if _, ok := c.Get("fresh"); !ok {
+		t.Error("Purge() should not remove the fresh entry")
+	}
+}
+
+func TestDiskCacheSetGetRoundTrip(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	want := CacheEntry{
+		Result:    &VerificationResult{MatchPercentage: 77, Notes: "n"},
+		RequestID: "req-123",
+		CreatedAt: time.Now(),
+	}
+	if err := c.Set("key", want); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := c.Get("key")
+	if !ok {
+		t.Fatal("Get() after Set() should hit")
+	}
+	if got.RequestID != want.RequestID {
+		t.Errorf("RequestID = %q, want %q", got.RequestID, want.RequestID)
+	}
+	if got.Result.MatchPercentage != want.Result.MatchPercentage {
+		t.Errorf("MatchPercentage = %d, want %d", got.Result.MatchPercentage, want.Result.MatchPercentage)
+	}
+}
+
+func TestDiskCacheTTLExpiry(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	if err := c.Set("key", CacheEntry{Result: &VerificationResult{}, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get() after the TTL elapsed should miss")
+	}
+}
+
+func TestDiskCachePurgeUsesStoredCreatedAtNotModTime(t *testing.T) {
+	// 回帰テスト: PurgeはファイルのModTimeではなく、レコードに保存されたCreatedAtで
+	// 判定しなければならない。ここではCreatedAtが古いが、ファイル自体はたった今
+	// 書き込まれた（＝ModTimeは新しい）エントリが正しく削除されることを確認する
+	c, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	oldCreatedAt := time.Now().Add(-24 * time.Hour)
+	if err := c.Set("stale", CacheEntry{Result: &VerificationResult{}, CreatedAt: oldCreatedAt}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := c.Set("fresh", CacheEntry{Result: &VerificationResult{}, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if err := c.Purge(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+
+	if _, ok := c.Get("stale"); ok {
+		t.Error("Purge() should have removed the entry whose stored CreatedAt predates olderThan, even though its file was just written")
+	}
+	if _, ok := c.Get("fresh"); !ok {
+		t.Error("Purge() should not remove an entry whose stored CreatedAt is recent")
+	}
+}
+
+func TestDefaultCacheDirUsesXDGCacheHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+
+	want := dir + "/gh-spec-verify"
+	if got := DefaultCacheDir(); got != want {
+		t.Errorf("DefaultCacheDir() = %q, want %q", got, want)
+	}
+}
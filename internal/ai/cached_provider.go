@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+// CachedProvider はProviderをラップし、SPEC＋コードのハッシュが一致する検証結果を
+// Cacheから再利用する。キャッシュヒット時はAPIを呼び出さずに即座に結果を返す
+type CachedProvider struct {
+	provider Provider
+	model    string
+	cache    Cache
+	noCache  bool
+}
+
+// NewCachedProvider はproviderの結果をcacheに保存・再利用するCachedProviderを作成する
+// modelはキャッシュキーの算出に使われる（Providerインターフェース自体はモデル名を
+// 公開していないため、呼び出し側から明示的に渡す）
+// noCacheがtrueの場合は常にキャッシュを読み書きせずproviderへ素通りする（--no-cache相当）
+func NewCachedProvider(provider Provider, model string, cache Cache, noCache bool) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		model:    model,
+		cache:    cache,
+		noCache:  noCache,
+	}
+}
+
+// Name はラップ元のプロバイダー名をそのまま返す
+func (c *CachedProvider) Name() string {
+	return c.provider.Name()
+}
+
+// Verify はキャッシュを確認し、ヒットすれば保存済みの結果を返す
+// ミスした場合はラップ元のVerifyを呼び出し、結果をAPIのrequest-idとタイムスタンプ付きで保存する
+func (c *CachedProvider) Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error) {
+	if c.noCache {
+		return c.provider.Verify(ctx, specContent, codeContents)
+	}
+
+	key := CacheKey(c.provider.Name(), c.model, specContent, codeContents)
+
+	if entry, ok := c.cache.Get(key); ok {
+		return entry.Result, nil
+	}
+
+	captureCtx, requestID := withRequestIDCapture(ctx)
+	result, err := c.provider.Verify(captureCtx, specContent, codeContents)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = c.cache.Set(key, CacheEntry{
+		Result:    result,
+		RequestID: *requestID,
+		CreatedAt: time.Now(),
+	})
+
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeProvider は呼び出し回数を記録し、呼び出されるたびにctxへrequest-idを書き込む
+// CachedProviderのキャッシュヒット/ミス挙動とrequest-idの伝搬を検証するためのテスト用Provider
+type fakeProvider struct {
+	name      string
+	calls     int
+	result    *VerificationResult
+	requestID string
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error) {
+	p.calls++
+	captureRequestID(ctx, p.requestID)
+	return p.result, nil
+}
+
+func TestCachedProviderMissThenHit(t *testing.T) {
+	inner := &fakeProvider{name: "fake", result: &VerificationResult{MatchPercentage: 90}, requestID: "req-abc"}
+	cache := NewMemoryCache(0)
+	cp := NewCachedProvider(inner, "model-x", cache, false)
+
+	ctx := context.Background()
+	files := map[string]string{"a.go": "package main"}
+
+	got, err := cp.Verify(ctx, "spec", files)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.MatchPercentage != 90 {
+		t.Errorf("MatchPercentage = %d, want 90", got.MatchPercentage)
+	}
+	if inner.calls != 1 {
+		t.Fatalf("calls = %d, want 1 after a cache miss", inner.calls)
+	}
+
+	key := CacheKey(inner.Name(), "model-x", "spec", files)
+	entry, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("expected the result to have been stored under the computed cache key")
+	}
+	if entry.RequestID != "req-abc" {
+		t.Errorf("stored RequestID = %q, want %q", entry.RequestID, "req-abc")
+	}
+
+	got2, err := cp.Verify(ctx, "spec", files)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got2.MatchPercentage != 90 {
+		t.Errorf("MatchPercentage = %d, want 90", got2.MatchPercentage)
+	}
+	if inner.calls != 1 {
+		t.Errorf("calls = %d, want 1 (second call should be served from cache)", inner.calls)
+	}
+}
+
+func TestCachedProviderNoCacheBypassesCache(t *testing.T) {
+	inner := &fakeProvider{name: "fake", result: &VerificationResult{MatchPercentage: 10}}
+	cache := NewMemoryCache(0)
+	cp := NewCachedProvider(inner, "model-x", cache, true)
+
+	ctx := context.Background()
+	files := map[string]string{"a.go": "package main"}
+
+	if _, err := cp.Verify(ctx, "spec", files); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if _, err := cp.Verify(ctx, "spec", files); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if inner.calls != 2 {
+		t.Errorf("calls = %d, want 2 (noCache should bypass the cache entirely)", inner.calls)
+	}
+
+	key := CacheKey(inner.Name(), "model-x", "spec", files)
+	if _, ok := cache.Get(key); ok {
+		t.Error("noCache should never populate the cache")
+	}
+}
+
+func TestCachedProviderNameDelegatesToWrappedProvider(t *testing.T) {
+	inner := &fakeProvider{name: "fake"}
+	cp := NewCachedProvider(inner, "model-x", NewMemoryCache(0), false)
+
+	if got := cp.Name(); got != "fake" {
+		t.Errorf("Name() = %q, want %q", got, "fake")
+	}
+}
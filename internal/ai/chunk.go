@@ -0,0 +1,145 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultMaxTokensPerChunk is used by VerifyLarge when ChunkOptions.MaxTokensPerChunk is unset
+const defaultMaxTokensPerChunk = 150_000
+
+// TokenEstimator estimates how many tokens a piece of text will consume once sent to the model
+// 実装を差し替えられるようにすることで、簡易な文字数ベースの見積もりから、将来的に
+// tiktoken相当の本格的なBPEベースの見積もりへの置き換えも可能にする
+type TokenEstimator interface {
+	Estimate(text string) int
+}
+
+// charCountEstimator は厳密なBPEトークナイザを持たない場合の簡易な見積もり実装
+// 経験則として「だいたい4文字で1トークン」という近似を採用する
+// 文字数としてはlen(text)のバイト長を使い、端数は切り上げる
+type charCountEstimator struct{}
+
+func (charCountEstimator) Estimate(text string) int {
+	if len(text) == 0 {
+		return 0
+	}
+	return (len(text) + 3) / 4
+}
+
+// DefaultTokenEstimator はlen(text)/4（バイト長、切り上げ）の簡易近似によるTokenEstimatorを返す
+func DefaultTokenEstimator() TokenEstimator {
+	return charCountEstimator{}
+}
+
+// ChunkOptions はVerifyLargeがコードをどう分割し、どう並列実行するかを制御する
+type ChunkOptions struct {
+	// MaxTokensPerChunk は各チャンクが収まるべきトークン数の上限（Estimatorによる見積もり）
+	// 0以下の場合はdefaultMaxTokensPerChunkが使われる
+	MaxTokensPerChunk int
+	// Concurrency はチャンクを並列検証するワーカー数の上限。0以下の場合は1（直列実行）
+	Concurrency int
+	// Estimator はトークン数の見積もりに使用する。nilの場合はDefaultTokenEstimator()が使われる
+	Estimator TokenEstimator
+}
+
+// codeChunk は1回のAPI呼び出しに収まるようまとめられたファイル群と、その合計トークン数見積もり
+type codeChunk struct {
+	files  map[string]string
+	tokens int
+}
+
+// partitionIntoChunks はcodeContentsを、各チャンクがmaxTokensPerChunkを超えないように
+// first-fitのbin-packingで振り分ける
+// 1ファイル単独でmaxTokensPerChunkを超える場合は、それ以上分割できないためそのファイル
+// 単体のチャンクとする
+func partitionIntoChunks(codeContents map[string]string, estimator TokenEstimator, maxTokensPerChunk int) []codeChunk {
+	paths := make([]string, 0, len(codeContents))
+	for path := range codeContents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var chunks []codeChunk
+	for _, path := range paths {
+		content := codeContents[path]
+		tokens := estimator.Estimate(content)
+
+		placed := false
+		for i := range chunks {
+			if chunks[i].tokens+tokens <= maxTokensPerChunk {
+				chunks[i].files[path] = content
+				chunks[i].tokens += tokens
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			chunks = append(chunks, codeChunk{
+				files:  map[string]string{path: content},
+				tokens: tokens,
+			})
+		}
+	}
+
+	return chunks
+}
+
+// reduceVerificationResults はチャンクごとの検証結果を1つの結果にまとめる（map-reduceのreduce段階）
+// matchedItems/unmatchedItemsは重複排除した上で結合し、matchPercentageはチャンクのトークン数で
+// 重み付け平均し、notesは見出し付きで連結する
+func reduceVerificationResults(results []*VerificationResult, tokens []int) *VerificationResult {
+	seenMatched := map[string]bool{}
+	seenUnmatched := map[string]bool{}
+	var matched, unmatched []string
+
+	var weightedSum float64
+	var totalWeight int
+	var notes strings.Builder
+
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+
+		for _, item := range r.MatchedItems {
+			if !seenMatched[item] {
+				seenMatched[item] = true
+				matched = append(matched, item)
+			}
+		}
+		for _, item := range r.UnmatchedItems {
+			if !seenUnmatched[item] {
+				seenUnmatched[item] = true
+				unmatched = append(unmatched, item)
+			}
+		}
+
+		weight := 1
+		if i < len(tokens) && tokens[i] > 0 {
+			weight = tokens[i]
+		}
+		weightedSum += float64(r.MatchPercentage) * float64(weight)
+		totalWeight += weight
+
+		if strings.TrimSpace(r.Notes) != "" {
+			if notes.Len() > 0 {
+				notes.WriteString("\n\n")
+			}
+			notes.WriteString(fmt.Sprintf("## Chunk %d\n%s", i+1, r.Notes))
+		}
+	}
+
+	matchPercentage := 0
+	if totalWeight > 0 {
+		matchPercentage = int(weightedSum/float64(totalWeight) + 0.5)
+	}
+
+	return &VerificationResult{
+		MatchPercentage: matchPercentage,
+		MatchedItems:    matched,
+		UnmatchedItems:  unmatched,
+		Notes:           notes.String(),
+	}
+}
@@ -0,0 +1,118 @@
+package ai
+
+import "testing"
+
+// byteCountEstimator is a deterministic TokenEstimator for tests: 1 token per byte.
+type byteCountEstimator struct{}
+
+func (byteCountEstimator) Estimate(text string) int {
+	return len(text)
+}
+
+func TestPartitionIntoChunksPacksFilesUnderBudget(t *testing.T) {
+	codeContents := map[string]string{
+		"a.go": "aaaaa", // 5 tokens
+		"b.go": "bbbbb", // 5 tokens
+		"c.go": "ccccc", // 5 tokens
+	}
+
+	chunks := partitionIntoChunks(codeContents, byteCountEstimator{}, 10)
+
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+
+	// files are visited in sorted path order (a, b, c); first-fit packs a+b into chunk 1
+	if _, ok := chunks[0].files["a.go"]; !ok {
+		t.Error("expected chunk 0 to contain a.go")
+	}
+	if _, ok := chunks[0].files["b.go"]; !ok {
+		t.Error("expected chunk 0 to contain b.go")
+	}
+	if chunks[0].tokens != 10 {
+		t.Errorf("chunk 0 tokens = %d, want 10", chunks[0].tokens)
+	}
+
+	if _, ok := chunks[1].files["c.go"]; !ok {
+		t.Error("expected chunk 1 to contain c.go")
+	}
+	if chunks[1].tokens != 5 {
+		t.Errorf("chunk 1 tokens = %d, want 5", chunks[1].tokens)
+	}
+}
+
+func TestPartitionIntoChunksOversizedFileGetsItsOwnChunk(t *testing.T) {
+	codeContents := map[string]string{
+		"huge.go": "0123456789", // 10 tokens, exceeds the budget on its own
+	}
+
+	chunks := partitionIntoChunks(codeContents, byteCountEstimator{}, 4)
+
+	if len(chunks) != 1 {
+		t.Fatalf("got %d chunks, want 1", len(chunks))
+	}
+	if chunks[0].tokens != 10 {
+		t.Errorf("chunk tokens = %d, want 10 (oversized file kept whole)", chunks[0].tokens)
+	}
+}
+
+func TestReduceVerificationResultsDedupsAndWeightsAverage(t *testing.T) {
+	results := []*VerificationResult{
+		{
+			MatchPercentage: 100,
+			MatchedItems:    []string{"login form", "validation"},
+			UnmatchedItems:  []string{"error banner"},
+			Notes:           "first chunk looks complete",
+		},
+		{
+			MatchPercentage: 0,
+			MatchedItems:    []string{"validation"}, // duplicate, should not be counted twice
+			UnmatchedItems:  []string{"logout flow"},
+			Notes:           "second chunk missing logout",
+		},
+	}
+	tokens := []int{300, 100} // first chunk is weighted 3x the second
+
+	got := reduceVerificationResults(results, tokens)
+
+	wantMatched := []string{"login form", "validation"}
+	if len(got.MatchedItems) != len(wantMatched) {
+		t.Fatalf("MatchedItems = %v, want %v", got.MatchedItems, wantMatched)
+	}
+	for i, item := range wantMatched {
+		if got.MatchedItems[i] != item {
+			t.Errorf("MatchedItems[%d] = %q, want %q", i, got.MatchedItems[i], item)
+		}
+	}
+
+	wantUnmatched := []string{"error banner", "logout flow"}
+	if len(got.UnmatchedItems) != len(wantUnmatched) {
+		t.Fatalf("UnmatchedItems = %v, want %v", got.UnmatchedItems, wantUnmatched)
+	}
+
+	// weighted average: (100*300 + 0*100) / 400 = 75
+	if got.MatchPercentage != 75 {
+		t.Errorf("MatchPercentage = %d, want 75", got.MatchPercentage)
+	}
+
+	if got.Notes == "" {
+		t.Error("expected non-empty aggregated notes")
+	}
+}
+
+func TestReduceVerificationResultsSkipsNilResults(t *testing.T) {
+	results := []*VerificationResult{
+		{MatchPercentage: 50, MatchedItems: []string{"x"}},
+		nil,
+	}
+	tokens := []int{100, 100}
+
+	got := reduceVerificationResults(results, tokens)
+
+	if got.MatchPercentage != 50 {
+		t.Errorf("MatchPercentage = %d, want 50", got.MatchPercentage)
+	}
+	if len(got.MatchedItems) != 1 || got.MatchedItems[0] != "x" {
+		t.Errorf("MatchedItems = %v, want [x]", got.MatchedItems)
+	}
+}
@@ -7,16 +7,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
-	"strings"
 )
 
 const claudeAPIURL = "https://api.anthropic.com/v1/messages"
 
 // ClaudeProvider はClaude APIを使用したプロバイダー
 type ClaudeProvider struct {
-	apiKey string
-	model  string
+	apiKey      string
+	model       string
+	retryPolicy RetryPolicy
 }
 
 // NewClaudeProvider は新しいClaudeProviderを作成する
@@ -26,8 +25,9 @@ func NewClaudeProvider(apiKey string) (*ClaudeProvider, error) {
 	}
 
 	return &ClaudeProvider{
-		apiKey: apiKey,
-		model:  "claude-sonnet-4-20250514",
+		apiKey:      apiKey,
+		model:       "claude-sonnet-4-20250514",
+		retryPolicy: DefaultRetryPolicy(),
 	}, nil
 }
 
@@ -41,6 +41,7 @@ type claudeRequest struct {
 	Model     string          `json:"model"`
 	MaxTokens int             `json:"max_tokens"`
 	Messages  []claudeMessage `json:"messages"`
+	Stream    bool            `json:"stream,omitempty"`
 }
 
 type claudeMessage struct {
@@ -61,9 +62,18 @@ type claudeResponse struct {
 }
 
 // Verify はSPECとコードの一致度を検証する
+// レート制限や過負荷などリトライ可能なエラーは、DefaultRetryPolicy()の設定に従って自動的に再試行する
+// ポリシーはNewClaudeProviderで固定され、パッケージの外から変更する手段はない
 func (p *ClaudeProvider) Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error) {
 	prompt := buildVerificationPrompt(specContent, codeContents)
 
+	return withRetry(ctx, p.retryPolicy, func() (*VerificationResult, error) {
+		return p.verifyOnce(ctx, prompt)
+	})
+}
+
+// verifyOnce はリトライなしで1回だけAPI呼び出しを行う
+func (p *ClaudeProvider) verifyOnce(ctx context.Context, prompt string) (*VerificationResult, error) {
 	req := claudeRequest{
 		Model:     p.model,
 		MaxTokens: 2000,
@@ -74,7 +84,7 @@ func (p *ClaudeProvider) Verify(ctx context.Context, specContent string, codeCon
 
 	reqBody, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, &APIError{Code: ErrCodeSerialization, Message: err.Error(), Err: err}
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", claudeAPIURL, bytes.NewReader(reqBody))
@@ -89,26 +99,28 @@ func (p *ClaudeProvider) Verify(ctx context.Context, specContent string, codeCon
 	client := &http.Client{}
 	resp, err := client.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, &APIError{Code: ErrCodeTransport, Message: err.Error(), Err: err}
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, &APIError{Code: ErrCodeTransport, Message: err.Error(), Err: err}
 	}
 
+	captureRequestID(ctx, resp.Header.Get("request-id"))
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, classifyAPIError(resp.StatusCode, body, resp.Header)
 	}
 
 	var claudeResp claudeResponse
 	if err := json.Unmarshal(body, &claudeResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, &APIError{Code: ErrCodeSerialization, Message: err.Error(), Err: err}
 	}
 
 	if claudeResp.Error != nil {
-		return nil, fmt.Errorf("API error: %s", claudeResp.Error.Message)
+		return nil, classifyAPIError(resp.StatusCode, body, resp.Header)
 	}
 
 	if len(claudeResp.Content) == 0 {
@@ -118,61 +130,15 @@ func (p *ClaudeProvider) Verify(ctx context.Context, specContent string, codeCon
 	return parseVerificationResult(claudeResp.Content[0].Text)
 }
 
-// buildVerificationPrompt は検証用のプロンプトを構築する
-func buildVerificationPrompt(specContent string, codeContents map[string]string) string {
-	var codeSection strings.Builder
-	for filePath, content := range codeContents {
-		codeSection.WriteString(fmt.Sprintf("\n### %s\n```\n%s\n```\n", filePath, content))
-	}
-
-	return fmt.Sprintf(`あなたはコードレビューの専門家です。以下のSPEC（仕様書）と実際のコードを比較して、一致度を評価してください。
-
-## SPEC（仕様書）
-%s
-
-## 実際のコード
-%s
-
-## 評価基準
-以下の観点で評価してください：
-1. 画面構成: SPECに記載された要素がコードに存在するか
-2. 状態管理: SPECに記載された状態やフックが使用されているか
-3. 処理フロー: SPECに記載された処理フローがコードで実装されているか
-4. バリデーション: SPECに記載されたバリデーションルールが実装されているか
-5. エラーハンドリング: SPECに記載されたエラーケースが処理されているか
-
-## 出力形式
-以下のJSON形式で出力してください：
-%sjson
-{
-  "matchPercentage": <0-100の数値>,
-  "matchedItems": ["一致している項目1", "一致している項目2", ...],
-  "unmatchedItems": ["一致していない項目1", "一致していない項目2", ...],
-  "notes": "補足コメント（未実装の機能や改善点など）"
-}
-%s
-
-JSONのみを出力してください。`, specContent, codeSection.String(), "```", "```")
-}
-
-// parseVerificationResult はClaude APIのレスポンスから検証結果を抽出する
-func parseVerificationResult(text string) (*VerificationResult, error) {
-	// JSONブロックを抽出
-	jsonRegex := regexp.MustCompile("```json\\s*([\\s\\S]*?)\\s*```")
-	matches := jsonRegex.FindStringSubmatch(text)
-
-	var jsonStr string
-	if len(matches) >= 2 {
-		jsonStr = matches[1]
-	} else {
-		// JSONブロックがない場合は直接パースを試みる
-		jsonStr = text
-	}
-
-	var result VerificationResult
-	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse verification result: %w", err)
-	}
-
-	return &result, nil
+func init() {
+	Register("claude", func(cfg ProviderConfig) (Provider, error) {
+		p, err := NewClaudeProvider(cfg.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Model != "" {
+			p.model = cfg.Model
+		}
+		return p, nil
+	})
 }
@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"context"
+	"sync"
+)
+
+// VerifyLarge はSourceSetが示すコードがモデルのコンテキスト窓に収まらないほど大きい場合に、
+// ChunkOptionsに従ってファイルをトークン予算内のチャンクへ分割し、各チャンクを並列にVerifyしてから
+// 結果を1つのVerificationResultへ集約する（map-reduceパターン）
+// 呼び出し側はファイルを手で振り分ける必要がない
+func (p *ClaudeProvider) VerifyLarge(ctx context.Context, specContent string, sources *SourceSet, opts ChunkOptions) (*VerificationResult, error) {
+	codeContents, err := collectSourceContents(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	estimator := opts.Estimator
+	if estimator == nil {
+		estimator = DefaultTokenEstimator()
+	}
+	maxTokensPerChunk := opts.MaxTokensPerChunk
+	if maxTokensPerChunk <= 0 {
+		maxTokensPerChunk = defaultMaxTokensPerChunk
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	chunks := partitionIntoChunks(codeContents, estimator, maxTokensPerChunk)
+	if len(chunks) <= 1 {
+		return p.Verify(ctx, specContent, codeContents)
+	}
+
+	results := make([]*VerificationResult, len(chunks))
+	errs := make([]error, len(chunks))
+	tokens := make([]int, len(chunks))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		tokens[i] = chunk.tokens
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk codeChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = p.Verify(ctx, specContent, chunk.files)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	for _, chunkErr := range errs {
+		if chunkErr != nil {
+			return nil, chunkErr
+		}
+	}
+
+	return reduceVerificationResults(results, tokens), nil
+}
@@ -0,0 +1,36 @@
+package ai
+
+import "context"
+
+// VerifyFromSourceSet はSourceSetが指すファイル群を読み込みながらVerifyを実行する
+// 呼び出し側があらかじめcodeContentsを組み立てる必要がなくなり、ディレクトリを
+// 指定するだけでファイルの発見・除外・サイズ予算の適用をSourceSetに任せられる
+func (p *ClaudeProvider) VerifyFromSourceSet(ctx context.Context, specContent string, sources *SourceSet) (*VerificationResult, error) {
+	codeContents, err := collectSourceContents(sources)
+	if err != nil {
+		return nil, err
+	}
+	return p.Verify(ctx, specContent, codeContents)
+}
+
+// VerifyStreamFromSourceSet はSourceSetが指すファイル群を読み込みながらVerifyStreamを実行する
+func (p *ClaudeProvider) VerifyStreamFromSourceSet(ctx context.Context, specContent string, sources *SourceSet) (<-chan VerifyEvent, error) {
+	codeContents, err := collectSourceContents(sources)
+	if err != nil {
+		return nil, err
+	}
+	return p.VerifyStream(ctx, specContent, codeContents)
+}
+
+// collectSourceContents はSourceSetをすべて走査し、(path, content)の組をmapにまとめる
+func collectSourceContents(sources *SourceSet) (map[string]string, error) {
+	contents := map[string]string{}
+	err := sources.Walk(func(path, content string) error {
+		contents[path] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
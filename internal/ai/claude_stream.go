@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// claudeStreamEvent はClaudeのSSEストリームにおける1つのdataペイロード
+// typeの値（message_start/content_block_delta/message_stop/errorなど）によって
+// どのフィールドが埋まるかが変わる
+type claudeStreamEvent struct {
+	Type  string `json:"type"`
+	Delta *struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta,omitempty"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// VerifyStream はSSEで段階的にレスポンスを受け取りながらSPECとコードの一致度を検証する
+// 返されるchanは、ストリームが終了した時点（最終イベントを送出した後）でcloseされる
+func (p *ClaudeProvider) VerifyStream(ctx context.Context, specContent string, codeContents map[string]string) (<-chan VerifyEvent, error) {
+	prompt := buildVerificationPrompt(specContent, codeContents)
+
+	req := claudeRequest{
+		Model:     p.model,
+		MaxTokens: 2000,
+		Stream:    true,
+		Messages: []claudeMessage{
+			{Role: "user", Content: prompt},
+		},
+	}
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, &APIError{Code: ErrCodeSerialization, Message: err.Error(), Err: err}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", claudeAPIURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", "2023-06-01")
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, &APIError{Code: ErrCodeTransport, Message: err.Error(), Err: err}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, classifyAPIError(resp.StatusCode, body, resp.Header)
+	}
+
+	events := make(chan VerifyEvent)
+	go streamClaudeEvents(resp.Body, events)
+
+	return events, nil
+}
+
+// streamClaudeEvents はSSEフレームを読み取り、テキストのdeltaと最終的な検証結果をeventsに送出する
+// body・eventsはこの関数が責任を持ってclose/returnする
+func streamClaudeEvents(body io.ReadCloser, events chan<- VerifyEvent) {
+	defer close(events)
+	defer body.Close()
+
+	var fullText strings.Builder
+	matchedItems := newIncrementalArrayExtractor("matchedItems")
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var dataLine string
+	handleFrame := func() {
+		if dataLine == "" {
+			return
+		}
+		defer func() { dataLine = "" }()
+
+		var evt claudeStreamEvent
+		if err := json.Unmarshal([]byte(dataLine), &evt); err != nil {
+			events <- VerifyEvent{Err: fmt.Errorf("failed to parse stream event: %w", err)}
+			return
+		}
+
+		switch evt.Type {
+		case "content_block_delta":
+			if evt.Delta == nil || evt.Delta.Type != "text_delta" {
+				return
+			}
+			fullText.WriteString(evt.Delta.Text)
+			events <- VerifyEvent{
+				Delta:        evt.Delta.Text,
+				MatchedItems: matchedItems.Extract(fullText.String()),
+			}
+
+		case "error":
+			if evt.Error != nil {
+				events <- VerifyEvent{Err: fmt.Errorf("API error: %s", evt.Error.Message)}
+			}
+
+		case "message_stop":
+			result, err := parseVerificationResult(fullText.String())
+			if err != nil {
+				events <- VerifyEvent{Done: true, Err: err}
+				return
+			}
+			events <- VerifyEvent{Done: true, Result: result}
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			dataLine = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "":
+			handleFrame()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		events <- VerifyEvent{Done: true, Err: fmt.Errorf("failed to read stream: %w", err)}
+	}
+}
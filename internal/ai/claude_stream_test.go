@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// sseFixture builds a minimal Claude-style SSE body out of the given data payloads.
+func sseFixture(dataLines ...string) io.ReadCloser {
+	var sb strings.Builder
+	for _, line := range dataLines {
+		sb.WriteString("data: ")
+		sb.WriteString(line)
+		sb.WriteString("\n\n")
+	}
+	return io.NopCloser(strings.NewReader(sb.String()))
+}
+
+func TestStreamClaudeEventsEmitsDeltasAndFinalResult(t *testing.T) {
+	body := sseFixture(
+		`{"type":"content_block_delta","delta":{"type":"text_delta","text":"{\"matchedItems\": [\"a\""}}`,
+		`{"type":"content_block_delta","delta":{"type":"text_delta","text":"],\"unmatchedItems\":[],\"matchPercentage\":80,\"notes\":\"ok\"}"}}`,
+		`{"type":"message_stop"}`,
+	)
+
+	events := make(chan VerifyEvent)
+	go streamClaudeEvents(body, events)
+
+	var deltas []string
+	var allMatched []string
+	var final *VerifyEvent
+
+	for evt := range events {
+		if evt.Err != nil {
+			t.Fatalf("unexpected event error: %v", evt.Err)
+		}
+		if evt.Delta != "" {
+			deltas = append(deltas, evt.Delta)
+		}
+		allMatched = append(allMatched, evt.MatchedItems...)
+		if evt.Done {
+			e := evt
+			final = &e
+		}
+	}
+
+	if len(deltas) != 2 {
+		t.Fatalf("got %d delta events, want 2", len(deltas))
+	}
+
+	// 回帰テスト: matchedItems配列が閉じた後のJSONの残り部分がmatchedItemsとして
+	// 紛れ込んでいないこと
+	if len(allMatched) != 1 || allMatched[0] != "a" {
+		t.Errorf("MatchedItems across the stream = %v, want [a]", allMatched)
+	}
+
+	if final == nil {
+		t.Fatal("expected a final Done event")
+	}
+	if final.Result == nil {
+		t.Fatal("expected the final event to carry a parsed VerificationResult")
+	}
+	if final.Result.MatchPercentage != 80 {
+		t.Errorf("MatchPercentage = %d, want 80", final.Result.MatchPercentage)
+	}
+	if final.Result.Notes != "ok" {
+		t.Errorf("Notes = %q, want %q", final.Result.Notes, "ok")
+	}
+}
+
+func TestStreamClaudeEventsSurfacesAPIErrorEvent(t *testing.T) {
+	body := sseFixture(
+		`{"type":"error","error":{"type":"overloaded_error","message":"overloaded"}}`,
+	)
+
+	events := make(chan VerifyEvent)
+	go streamClaudeEvents(body, events)
+
+	var sawErr bool
+	for evt := range events {
+		if evt.Err != nil {
+			sawErr = true
+		}
+	}
+
+	if !sawErr {
+		t.Error("expected an event with Err set for a mid-stream error frame")
+	}
+}
@@ -0,0 +1,122 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrorCode はAPIエラーの分類
+type ErrorCode string
+
+const (
+	// ErrCodeRateLimited はレート制限超過（HTTP 429など）
+	ErrCodeRateLimited ErrorCode = "RateLimited"
+	// ErrCodeOverloaded はサーバー過負荷（HTTP 529など）
+	ErrCodeOverloaded ErrorCode = "Overloaded"
+	// ErrCodeAuthFailed は認証エラー（HTTP 401/403）
+	ErrCodeAuthFailed ErrorCode = "AuthFailed"
+	// ErrCodeInvalidRequest はリクエスト内容が不正（HTTP 400）
+	ErrCodeInvalidRequest ErrorCode = "InvalidRequest"
+	// ErrCodeSerialization はリクエスト/レスポンスのJSON変換エラー
+	ErrCodeSerialization ErrorCode = "Serialization"
+	// ErrCodeTransport はHTTP通信そのものの失敗（DNS、タイムアウトなど）
+	ErrCodeTransport ErrorCode = "Transport"
+	// ErrCodeUnknown は上記のいずれにも分類できないエラー
+	ErrCodeUnknown ErrorCode = "Unknown"
+)
+
+// APIError はAIプロバイダーAPIとのやり取りで発生したエラーを分類して表す
+// 呼び出し側はCodeで分岐したり、IsRetryableでリトライ可否を判定できる
+type APIError struct {
+	Code       ErrorCode
+	StatusCode int
+	Message    string
+	RequestID  string
+	// RetryAfter はサーバーが明示したリトライ猶予時間（retry-afterヘッダー由来）。未指定の場合はゼロ値
+	RetryAfter time.Duration
+	// Err は下位のエラー（通信エラーやJSONパースエラー）をラップする
+	Err error
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s (status %d, request_id=%s): %s", e.Code, e.StatusCode, e.RequestID, e.Message)
+	}
+	return fmt.Sprintf("%s (status %d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// Unwrap により errors.As / errors.Is でラップ元のエラーを辿れるようにする
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable はこのエラーがリトライに値するかどうかを返す
+// レート制限・過負荷・通信エラーはリトライ対象、認証エラーやリクエスト不正は対象外とする
+func (e *APIError) IsRetryable() bool {
+	switch e.Code {
+	case ErrCodeRateLimited, ErrCodeOverloaded, ErrCodeTransport:
+		return true
+	case ErrCodeAuthFailed, ErrCodeInvalidRequest, ErrCodeSerialization:
+		return false
+	default:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+}
+
+// anthropicErrorBody はAnthropic APIのエラーレスポンスに共通する形式
+type anthropicErrorBody struct {
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// classifyAPIError はHTTPステータス・レスポンスボディ・ヘッダーから APIError を組み立てる
+func classifyAPIError(statusCode int, body []byte, header http.Header) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		RequestID:  header.Get("request-id"),
+		RetryAfter: parseRetryAfter(header.Get("retry-after")),
+		Message:    string(body),
+	}
+
+	var parsed anthropicErrorBody
+	var errType string
+	if json.Unmarshal(body, &parsed) == nil && parsed.Error != nil {
+		errType = parsed.Error.Type
+		apiErr.Message = parsed.Error.Message
+	}
+
+	switch {
+	case errType == "overloaded_error" || statusCode == 529:
+		apiErr.Code = ErrCodeOverloaded
+	case errType == "rate_limit_error" || statusCode == http.StatusTooManyRequests:
+		apiErr.Code = ErrCodeRateLimited
+	case errType == "authentication_error" || errType == "permission_error" ||
+		statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		apiErr.Code = ErrCodeAuthFailed
+	case errType == "invalid_request_error" || statusCode == http.StatusBadRequest:
+		apiErr.Code = ErrCodeInvalidRequest
+	case statusCode >= http.StatusInternalServerError:
+		apiErr.Code = ErrCodeOverloaded
+	default:
+		apiErr.Code = ErrCodeUnknown
+	}
+
+	return apiErr
+}
+
+// parseRetryAfter はretry-afterヘッダー（秒数）をtime.Durationに変換する。値が空または不正な場合はゼロ値を返す
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
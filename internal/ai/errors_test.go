@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		header         http.Header
+		wantCode       ErrorCode
+		wantRetryable  bool
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:          "overloaded by anthropic error type",
+			statusCode:    http.StatusServiceUnavailable,
+			body:          `{"error":{"type":"overloaded_error","message":"overloaded"}}`,
+			header:        http.Header{},
+			wantCode:      ErrCodeOverloaded,
+			wantRetryable: true,
+		},
+		{
+			name:           "rate limited with retry-after",
+			statusCode:     http.StatusTooManyRequests,
+			body:           `{"error":{"type":"rate_limit_error","message":"slow down"}}`,
+			header:         http.Header{"Retry-After": []string{"2"}},
+			wantCode:       ErrCodeRateLimited,
+			wantRetryable:  true,
+			wantRetryAfter: 2 * time.Second,
+		},
+		{
+			name:          "auth failed",
+			statusCode:    http.StatusUnauthorized,
+			body:          `{"error":{"type":"authentication_error","message":"bad key"}}`,
+			header:        http.Header{},
+			wantCode:      ErrCodeAuthFailed,
+			wantRetryable: false,
+		},
+		{
+			name:          "invalid request",
+			statusCode:    http.StatusBadRequest,
+			body:          `{"error":{"type":"invalid_request_error","message":"bad request"}}`,
+			header:        http.Header{},
+			wantCode:      ErrCodeInvalidRequest,
+			wantRetryable: false,
+		},
+		{
+			name:          "generic server error treated as retryable",
+			statusCode:    http.StatusInternalServerError,
+			body:          `not json`,
+			header:        http.Header{},
+			wantCode:      ErrCodeOverloaded,
+			wantRetryable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyAPIError(tt.statusCode, []byte(tt.body), tt.header)
+
+			if err.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", err.Code, tt.wantCode)
+			}
+			if err.IsRetryable() != tt.wantRetryable {
+				t.Errorf("IsRetryable() = %v, want %v", err.IsRetryable(), tt.wantRetryable)
+			}
+			if err.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", err.RetryAfter, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-1", 0},
+		{"not-a-number", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
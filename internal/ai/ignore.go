@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreMatcher は1つの.gitignore/.specverifyignoreファイルから読み込んだ除外ルールの集合
+// ルールは記述順に評価し、後から書かれたルールほど優先される（"!"による打ち消しも含め
+// git(1)のgitignore仕様の基本的な部分のみをサポートする軽量な実装）
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern  string
+	negate   bool
+	anchored bool
+}
+
+// loadIgnoreFile はpathの内容を読み込み、コメント行・空行を除いたルールを返す
+// ファイルが存在しない場合はルールなしのマッチャーを返す（エラーにはしない）
+func loadIgnoreFile(path string) (*ignoreMatcher, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ignoreMatcher{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	m := &ignoreMatcher{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negate := strings.HasPrefix(line, "!")
+		if negate {
+			line = strings.TrimPrefix(line, "!")
+		}
+		line = strings.TrimSuffix(line, "/")
+
+		// 先頭の"/"はignoreファイルのディレクトリを起点とするアンカー指定であることを示す
+		// （例: "/dist"はそのディレクトリ直下のdistのみを指し、どこにあってもよいdistとは区別する）
+		anchored := strings.HasPrefix(line, "/")
+		if anchored {
+			line = strings.TrimPrefix(line, "/")
+		}
+
+		m.rules = append(m.rules, ignoreRule{pattern: line, negate: negate, anchored: anchored})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Match はrelPath（スラッシュ区切り、ignoreファイルのあるディレクトリからの相対パス）が
+// このマッチャーのルールによって除外対象となるかどうかを返す
+func (m *ignoreMatcher) Match(relPath string) bool {
+	ignored := false
+	for _, r := range m.rules {
+		if matchesIgnoreRule(r, relPath) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// matchesIgnoreRule はruleがrelPathにマッチするかどうかを判定する
+// アンカー指定（先頭"/"）のパターンはignoreファイルのディレクトリを起点とした位置でしか
+// マッチさせず、ファイル名のみや中間ディレクトリ名へのフォールバックは行わない
+func matchesIgnoreRule(rule ignoreRule, relPath string) bool {
+	if rule.anchored {
+		return matchesAnchoredPattern(rule.pattern, relPath)
+	}
+
+	if matched, _ := filepath.Match(rule.pattern, relPath); matched {
+		return true
+	}
+	if matched, _ := filepath.Match(rule.pattern, filepath.Base(relPath)); matched {
+		return true
+	}
+	for _, segment := range strings.Split(relPath, "/") {
+		if matched, _ := filepath.Match(rule.pattern, segment); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnchoredPattern はrelPathの先頭からpatternと同じ深さ分のセグメントを切り出し、
+// それがpatternにマッチするかを見る。これにより"/dist"は"dist"や"dist/app.js"には
+// マッチしても、"sub/dist"のようなネストした位置のdistにはマッチしない
+func matchesAnchoredPattern(pattern, relPath string) bool {
+	patternSegments := strings.Split(pattern, "/")
+	pathSegments := strings.Split(relPath, "/")
+	if len(pathSegments) < len(patternSegments) {
+		return false
+	}
+
+	prefix := strings.Join(pathSegments[:len(patternSegments)], "/")
+	matched, _ := filepath.Match(pattern, prefix)
+	return matched
+}
@@ -0,0 +1,102 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreMatcherAnchoredPattern(t *testing.T) {
+	// 回帰テスト: 先頭"/"のアンカー指定パターンは、ignoreファイルのディレクトリを起点とした
+	// 位置のみにマッチし、ネストした位置の同名ファイル/ディレクトリにはマッチしない
+	m := &ignoreMatcher{rules: []ignoreRule{{pattern: "dist", anchored: true}}}
+
+	tests := []struct {
+		relPath string
+		want    bool
+	}{
+		{"dist", true},
+		{"dist/app.js", true},
+		{"sub/dist", false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.relPath); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.relPath, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreMatcherUnanchoredPattern(t *testing.T) {
+	// アンカーなしのパターンはどの深さでもマッチする
+	m := &ignoreMatcher{rules: []ignoreRule{{pattern: "node_modules", anchored: false}}}
+
+	tests := []struct {
+		relPath string
+		want    bool
+	}{
+		{"node_modules", true},
+		{"a/node_modules", true},
+		{"a/node_modules/b.js", true}, // "node_modules" appears as a path segment
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.relPath); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.relPath, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreMatcherNegation(t *testing.T) {
+	m := &ignoreMatcher{rules: []ignoreRule{
+		{pattern: "*.log", anchored: false},
+		{pattern: "keep.log", anchored: false, negate: true},
+	}}
+
+	if !m.Match("debug.log") {
+		t.Error("Match(debug.log) = false, want true")
+	}
+	if m.Match("keep.log") {
+		t.Error("Match(keep.log) = true, want false (negated by a later rule)")
+	}
+}
+
+func TestLoadIgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+	content := "# comment\n\n*.log\n/dist\n!important.log\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	m, err := loadIgnoreFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		t.Fatalf("loadIgnoreFile() error = %v", err)
+	}
+
+	if len(m.rules) != 3 {
+		t.Fatalf("got %d rules, want 3 (comments/blank lines excluded)", len(m.rules))
+	}
+
+	if !m.Match("debug.log") {
+		t.Error("Match(debug.log) = false, want true")
+	}
+	if m.Match("important.log") {
+		t.Error("Match(important.log) = true, want false (negated)")
+	}
+	if !m.Match("dist") {
+		t.Error("Match(dist) = false, want true (anchored pattern)")
+	}
+	if m.Match("sub/dist") {
+		t.Error("Match(sub/dist) = true, want false (anchored pattern shouldn't match nested dirs)")
+	}
+}
+
+func TestLoadIgnoreFileMissing(t *testing.T) {
+	m, err := loadIgnoreFile(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("loadIgnoreFile() error = %v, want nil for a missing file", err)
+	}
+	if len(m.rules) != 0 {
+		t.Errorf("got %d rules, want 0 for a missing file", len(m.rules))
+	}
+}
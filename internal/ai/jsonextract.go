@@ -0,0 +1,96 @@
+package ai
+
+import "strings"
+
+// incrementalArrayExtractor は、まだ閉じていない可能性のある部分的なJSON文字列から
+// 指定したキーの文字列配列の要素を、確定した分だけ逐次取り出すための軽量なスキャナー
+// parseVerificationResultのような完全なJSONデコードと異なり、ストリーミング中の
+// 途中経過（配列が"]"で閉じる前の状態）からでも既に確定した要素を抽出できる
+type incrementalArrayExtractor struct {
+	key     string
+	emitted int
+}
+
+// newIncrementalArrayExtractor は指定したキーの配列を対象とするエクストラクターを作成する
+func newIncrementalArrayExtractor(key string) *incrementalArrayExtractor {
+	return &incrementalArrayExtractor{key: key}
+}
+
+// Extract はbufから未送出の確定済み文字列要素のみを返す
+// bufは呼び出すたびに増加していく前提（蓄積済みのテキスト全体）で、内部状態だけで
+// 前回までに送出済みの要素数を覚えておく
+func (e *incrementalArrayExtractor) Extract(buf string) []string {
+	keyIdx := strings.Index(buf, `"`+e.key+`"`)
+	if keyIdx == -1 {
+		return nil
+	}
+
+	bracketIdx := strings.IndexByte(buf[keyIdx:], '[')
+	if bracketIdx == -1 {
+		return nil
+	}
+	arrayBody := buf[keyIdx+bracketIdx+1:]
+
+	items := scanCompletedStrings(arrayBody)
+	if e.emitted >= len(items) {
+		return nil
+	}
+
+	fresh := items[e.emitted:]
+	e.emitted = len(items)
+	return fresh
+}
+
+// scanCompletedStrings はJSON配列の中身を先頭から走査し、開いて閉じたことが確認できた
+// 文字列リテラルだけを順番に返す。配列自体やJSON全体が閉じていなくても動作するが、
+// 対象の配列を閉じる（ネストの深さ0の）"]"に到達した時点で走査を打ち切り、それ以降に
+// 続くキーや値（次のフィールドのテキストなど）を誤って要素として拾わないようにする
+func scanCompletedStrings(arrayBody string) []string {
+	var items []string
+	var current strings.Builder
+
+	inString := false
+	escaped := false
+	depth := 0
+
+	for i := 0; i < len(arrayBody); i++ {
+		c := arrayBody[i]
+
+		if !inString {
+			switch c {
+			case '"':
+				inString = true
+			case '[', '{':
+				depth++
+			case ']', '}':
+				if depth == 0 {
+					// 対象配列自身の閉じ括弧に到達したので、ここで走査を終える
+					return items
+				}
+				depth--
+			}
+			continue
+		}
+
+		if escaped {
+			current.WriteByte(c)
+			escaped = false
+			continue
+		}
+
+		switch c {
+		case '\\':
+			escaped = true
+		case '"':
+			inString = false
+			if depth == 0 {
+				items = append(items, current.String())
+			}
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	return items
+}
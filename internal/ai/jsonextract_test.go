@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIncrementalArrayExtractorStopsAtClosingBracket(t *testing.T) {
+	// 回帰テスト: matchedItems配列が閉じた後に続くキーや値(unmatchedItems, notesなど)を
+	// 要素として誤って拾ってはいけない
+	buf := `{"matchedItems": ["a","b"], "unmatchedItems": ["c"], "notes": "x"}`
+
+	e := newIncrementalArrayExtractor("matchedItems")
+	got := e.Extract(buf)
+
+	want := []string{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() = %v, want %v", got, want)
+	}
+}
+
+func TestIncrementalArrayExtractorYieldsOnlyNewItems(t *testing.T) {
+	e := newIncrementalArrayExtractor("matchedItems")
+
+	got := e.Extract(`{"matchedItems": ["a"`)
+	if !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("first Extract() = %v, want [a]", got)
+	}
+
+	got = e.Extract(`{"matchedItems": ["a","b"`)
+	if !reflect.DeepEqual(got, []string{"b"}) {
+		t.Fatalf("second Extract() = %v, want [b] (only the newly completed item)", got)
+	}
+
+	got = e.Extract(`{"matchedItems": ["a","b"], "unmatchedItems": ["c"]}`)
+	if got != nil {
+		t.Fatalf("third Extract() = %v, want nil (array already closed, nothing new)", got)
+	}
+}
+
+func TestIncrementalArrayExtractorMissingKey(t *testing.T) {
+	e := newIncrementalArrayExtractor("matchedItems")
+	if got := e.Extract(`{"notes": "still streaming"}`); got != nil {
+		t.Errorf("Extract() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const openAIAPIURL = "https://api.openai.com/v1/chat/completions"
+
+// OpenAIProvider はOpenAI APIを使用したプロバイダー
+type OpenAIProvider struct {
+	apiKey string
+	model  string
+}
+
+// NewOpenAIProvider は新しいOpenAIProviderを作成する
+func NewOpenAIProvider(apiKey string) (*OpenAIProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+
+	return &OpenAIProvider{
+		apiKey: apiKey,
+		model:  "gpt-4o",
+	}, nil
+}
+
+// Name はプロバイダー名を返す
+func (p *OpenAIProvider) Name() string {
+	return "openai"
+}
+
+// openAIRequest はOpenAI APIへのリクエスト
+type openAIRequest struct {
+	Model    string              `json:"model,omitempty"`
+	Messages []openAIChatMessage `json:"messages"`
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIResponse はOpenAI APIからのレスポンス
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIChatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Verify はSPECとコードの一致度を検証する
+func (p *OpenAIProvider) Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error) {
+	prompt := buildVerificationPrompt(specContent, codeContents)
+
+	req := openAIRequest{
+		Model: p.model,
+		Messages: []openAIChatMessage{
+			{Role: "user", Content: prompt},
+		},
+	}
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", openAIAPIURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	captureRequestID(ctx, resp.Header.Get("x-request-id"))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var oaResp openAIResponse
+	if err := json.Unmarshal(body, &oaResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if oaResp.Error != nil {
+		return nil, fmt.Errorf("API error: %s", oaResp.Error.Message)
+	}
+
+	if len(oaResp.Choices) == 0 {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	return parseVerificationResult(oaResp.Choices[0].Message.Content)
+}
+
+func init() {
+	Register("openai", func(cfg ProviderConfig) (Provider, error) {
+		p, err := NewOpenAIProvider(cfg.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Model != "" {
+			p.model = cfg.Model
+		}
+		return p, nil
+	})
+}
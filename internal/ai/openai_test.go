@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIRequestMarshalOmitsEmptyModel(t *testing.T) {
+	req := openAIRequest{
+		Messages: []openAIChatMessage{{Role: "user", Content: "hello"}},
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(body), `"model"`) {
+		t.Errorf("body = %s, want no \"model\" field when Model is empty (omitempty)", body)
+	}
+
+	req.Model = "gpt-4o"
+	body, err = json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(body), `"model":"gpt-4o"`) {
+		t.Errorf("body = %s, want it to include the model field once set", body)
+	}
+}
+
+func TestOpenAIResponseUnmarshalChoices(t *testing.T) {
+	raw := `{"choices":[{"message":{"role":"assistant","content":"the verification result"}}]}`
+
+	var resp openAIResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if resp.Error != nil {
+		t.Fatalf("Error = %v, want nil", resp.Error)
+	}
+	if len(resp.Choices) != 1 {
+		t.Fatalf("got %d choices, want 1", len(resp.Choices))
+	}
+	if resp.Choices[0].Message.Content != "the verification result" {
+		t.Errorf("Content = %q, want %q", resp.Choices[0].Message.Content, "the verification result")
+	}
+}
+
+func TestOpenAIResponseUnmarshalError(t *testing.T) {
+	raw := `{"error":{"type":"invalid_request_error","message":"bad request"}}`
+
+	var resp openAIResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if resp.Error == nil {
+		t.Fatal("Error = nil, want non-nil")
+	}
+	if resp.Error.Message != "bad request" {
+		t.Errorf("Error.Message = %q, want %q", resp.Error.Message, "bad request")
+	}
+	if len(resp.Choices) != 0 {
+		t.Errorf("got %d choices, want 0", len(resp.Choices))
+	}
+}
+
+func TestNewOpenAIProviderRequiresAPIKey(t *testing.T) {
+	if _, err := NewOpenAIProvider(""); err == nil {
+		t.Error("NewOpenAIProvider(\"\") should return an error")
+	}
+
+	p, err := NewOpenAIProvider("sk-test")
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	if p.Name() != "openai" {
+		t.Errorf("Name() = %q, want %q", p.Name(), "openai")
+	}
+}
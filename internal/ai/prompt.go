@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// buildVerificationPrompt は検証用のプロンプトを構築する
+// 全プロバイダー共通のプロンプト/パース・パイプラインであり、
+// モデル固有のメッセージ形式への変換は各プロバイダー側で行う
+func buildVerificationPrompt(specContent string, codeContents map[string]string) string {
+	var codeSection strings.Builder
+	for filePath, content := range codeContents {
+		codeSection.WriteString(fmt.Sprintf("\n### %s\n```\n%s\n```\n", filePath, content))
+	}
+
+	return fmt.Sprintf(`あなたはコードレビューの専門家です。以下のSPEC（仕様書）と実際のコードを比較して、一致度を評価してください。
+
+## SPEC（仕様書）
+%s
+
+## 実際のコード
+%s
+
+## 評価基準
+以下の観点で評価してください：
+1. 画面構成: SPECに記載された要素がコードに存在するか
+2. 状態管理: SPECに記載された状態やフックが使用されているか
+3. 処理フロー: SPECに記載された処理フローがコードで実装されているか
+4. バリデーション: SPECに記載されたバリデーションルールが実装されているか
+5. エラーハンドリング: SPECに記載されたエラーケースが処理されているか
+
+## 出力形式
+以下のJSON形式で出力してください：
+%sjson
+{
+  "matchPercentage": <0-100の数値>,
+  "matchedItems": ["一致している項目1", "一致している項目2", ...],
+  "unmatchedItems": ["一致していない項目1", "一致していない項目2", ...],
+  "notes": "補足コメント（未実装の機能や改善点など）"
+}
+%s
+
+JSONのみを出力してください。`, specContent, codeSection.String(), "```", "```")
+}
+
+// parseVerificationResult はモデルのテキスト出力から検証結果を抽出する
+// 全プロバイダー共通で、```json ... ``` で囲まれた応答と素のJSON応答の両方に対応する
+func parseVerificationResult(text string) (*VerificationResult, error) {
+	// JSONブロックを抽出
+	jsonRegex := regexp.MustCompile("```json\\s*([\\s\\S]*?)\\s*```")
+	matches := jsonRegex.FindStringSubmatch(text)
+
+	var jsonStr string
+	if len(matches) >= 2 {
+		jsonStr = matches[1]
+	} else {
+		// JSONブロックがない場合は直接パースを試みる
+		jsonStr = text
+	}
+
+	var result VerificationResult
+	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse verification result: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ProviderFactory はProviderConfigからProviderを生成する関数
+type ProviderFactory func(cfg ProviderConfig) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ProviderFactory{}
+)
+
+// Register はプロバイダー名とファクトリ関数をレジストリに登録する
+// 同名のプロバイダーが既に登録されている場合は上書きする
+func Register(name string, factory ProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New は登録済みのファクトリから指定した名前のProviderを生成する
+func New(name string, cfg ProviderConfig) (Provider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown provider: %s", name)
+	}
+
+	return factory(cfg)
+}
+
+// Providers は登録済みのプロバイダー名の一覧を返す
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterAndNew(t *testing.T) {
+	name := "test-provider-registry"
+	want := &OpenAIProvider{apiKey: "k", model: "m"}
+
+	Register(name, func(cfg ProviderConfig) (Provider, error) {
+		return want, nil
+	})
+
+	got, err := New(name, ProviderConfig{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got != Provider(want) {
+		t.Errorf("New() = %v, want %v", got, want)
+	}
+}
+
+func TestNewUnknownProvider(t *testing.T) {
+	if _, err := New("does-not-exist-provider", ProviderConfig{}); err == nil {
+		t.Error("New() with an unregistered name should return an error")
+	}
+}
+
+func TestProvidersListsRegisteredNames(t *testing.T) {
+	name := "test-provider-listing"
+	Register(name, func(cfg ProviderConfig) (Provider, error) { return nil, nil })
+
+	found := false
+	for _, n := range Providers() {
+		if n == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("Providers() = %v, want it to include %q", Providers(), name)
+	}
+}
+
+func TestRegisterOverwritesExistingFactory(t *testing.T) {
+	name := "test-provider-overwrite"
+	Register(name, func(cfg ProviderConfig) (Provider, error) { return nil, errors.New("first") })
+	Register(name, func(cfg ProviderConfig) (Provider, error) { return nil, errors.New("second") })
+
+	_, err := New(name, ProviderConfig{})
+	if err == nil || err.Error() != "second" {
+		t.Errorf("New() error = %v, want the most recently registered factory's error", err)
+	}
+}
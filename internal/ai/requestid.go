@@ -0,0 +1,22 @@
+package ai
+
+import "context"
+
+// requestIDCaptureKey は、Providerの実装がAPI呼び出しのrequest-idを呼び出し元へ
+// 報告するために使うcontextキー。キャッシュ用途のためだけにProviderインターフェース
+// 自体を広げずに済む
+type requestIDCaptureKey struct{}
+
+// withRequestIDCapture は、Providerがリクエスト中に発生したrequest-idをcaptureRequestID経由で
+// 書き込めるcontextと、Verifyの呼び出し元がその結果を読み取るためのポインタを返す
+func withRequestIDCapture(ctx context.Context) (context.Context, *string) {
+	var id string
+	return context.WithValue(ctx, requestIDCaptureKey{}, &id), &id
+}
+
+// captureRequestID はwithRequestIDCaptureでctxに登録されたポインタがあれば、そこにidを書き込む
+func captureRequestID(ctx context.Context, id string) {
+	if ptr, ok := ctx.Value(requestIDCaptureKey{}).(*string); ok {
+		*ptr = id
+	}
+}
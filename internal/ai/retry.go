@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy はリトライ時の試行回数と待機時間を制御する
+type RetryPolicy struct {
+	// MaxAttempts は初回呼び出しを含む最大試行回数
+	MaxAttempts int
+	// BaseDelay は1回目のリトライ待機時間の基準値
+	BaseDelay time.Duration
+	// MaxDelay は待機時間の上限
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy は標準的なリトライ設定を返す
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// withRetry はfnを実行し、*APIErrorでIsRetryable()がtrueを返す間はフルジッター付き指数バックオフで再試行する
+// retry-afterヘッダーが指定されている場合はバックオフ計算より優先する
+func withRetry(ctx context.Context, policy RetryPolicy, fn func() (*VerificationResult, error)) (*VerificationResult, error) {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsRetryable() {
+			return nil, err
+		}
+
+		if attempt == policy.MaxAttempts-1 {
+			break
+		}
+
+		delay := fullJitterBackoff(policy, attempt)
+		if apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// fullJitterBackoff は base * 2^attempt を上限MaxDelayでクランプし、[0, upper]の一様乱数を返す（AWS推奨のfull jitter方式）
+func fullJitterBackoff(policy RetryPolicy, attempt int) time.Duration {
+	upper := policy.BaseDelay * time.Duration(uint64(1)<<uint(attempt))
+	if upper <= 0 || upper > policy.MaxDelay {
+		upper = policy.MaxDelay
+	}
+	if upper <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(upper) + 1))
+}
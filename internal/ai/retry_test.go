@@ -0,0 +1,92 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    2 * time.Millisecond,
+	}
+}
+
+func TestWithRetrySucceedsOnFirstTry(t *testing.T) {
+	calls := 0
+	want := &VerificationResult{MatchPercentage: 100}
+
+	got, err := withRetry(context.Background(), testRetryPolicy(5), func() (*VerificationResult, error) {
+		calls++
+		return want, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestWithRetryRetriesRetryableErrors(t *testing.T) {
+	calls := 0
+	want := &VerificationResult{MatchPercentage: 42}
+
+	got, err := withRetry(context.Background(), testRetryPolicy(5), func() (*VerificationResult, error) {
+		calls++
+		if calls < 3 {
+			return nil, &APIError{Code: ErrCodeOverloaded, StatusCode: 529}
+		}
+		return want, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestWithRetryStopsOnNonRetryableError(t *testing.T) {
+	calls := 0
+	wantErr := &APIError{Code: ErrCodeAuthFailed, StatusCode: 401}
+
+	_, err := withRetry(context.Background(), testRetryPolicy(5), func() (*VerificationResult, error) {
+		calls++
+		return nil, wantErr
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (should not retry a non-retryable error)", calls)
+	}
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+
+	_, err := withRetry(context.Background(), testRetryPolicy(3), func() (*VerificationResult, error) {
+		calls++
+		return nil, &APIError{Code: ErrCodeRateLimited, StatusCode: 429}
+	})
+
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (== MaxAttempts)", calls)
+	}
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// signAWSRequestV4 はAWS Signature Version 4でHTTPリクエストに署名し、Authorizationヘッダーを設定する
+// Bedrockのinvoke-model呼び出し専用の最小実装であり、クエリ文字列やマルチパートボディなど一般用途は対象外とする
+func signAWSRequestV4(req *http.Request, body []byte, accessKeyID, secretAccessKey, sessionToken, region, service string, now time.Time) error {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	dateStamp := now.UTC().Format("20060102")
+
+	req.Header.Set("host", req.URL.Host)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", sha256Hex(body))
+	if sessionToken != "" {
+		req.Header.Set("x-amz-security-token", sessionToken)
+	}
+
+	canonicalHeaders, signedHeaders := canonicalizeSignedHeaders(req.Header)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := deriveSigningKey(secretAccessKey, dateStamp, region, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, credentialScope, signedHeaders, signature,
+	))
+
+	return nil
+}
+
+// canonicalURI は空のパスをルートパスとして正規化する
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// canonicalizeSignedHeaders は署名対象ヘッダー（host, x-amz-date, x-amz-content-sha256, x-amz-security-token）を
+// SigV4の仕様に従って正規化し、正規化済みヘッダー文字列と署名済みヘッダー名の一覧を返す
+func canonicalizeSignedHeaders(header http.Header) (canonical string, signed string) {
+	signedNames := []string{"host", "x-amz-content-sha256", "x-amz-date", "x-amz-security-token"}
+
+	names := make([]string, 0, len(signedNames))
+	values := map[string]string{}
+	for _, name := range signedNames {
+		if v := header.Get(name); v != "" {
+			names = append(names, name)
+			values[name] = strings.TrimSpace(v)
+		}
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteString(":")
+		sb.WriteString(values[name])
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), strings.Join(names, ";")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func deriveSigningKey(secretAccessKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretAccessKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
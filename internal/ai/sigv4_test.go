@@ -0,0 +1,86 @@
+package ai
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestSignAWSRequestV4KnownVector は、独立に計算した正規リクエスト/署名と突き合わせる回帰テスト
+// （AWSのSigV4サンプル資格情報 AKIDEXAMPLE を用い、カノニカライズ・署名鍵導出・ペイロードハッシュの
+// いずれかが壊れた場合に検知できるようにする）
+func TestSignAWSRequestV4KnownVector(t *testing.T) {
+	body := []byte(`{"prompt":"hi"}`)
+	req, err := http.NewRequest(http.MethodPost, "https://bedrock-runtime.us-east-1.amazonaws.com/model/test-model/invoke", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	now := time.Date(2011, 9, 9, 23, 36, 0, 0, time.UTC)
+	if err := signAWSRequestV4(req, body, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLE", "", "us-east-1", "bedrock", now); err != nil {
+		t.Fatalf("signAWSRequestV4() error = %v", err)
+	}
+
+	wantAuth := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20110909/us-east-1/bedrock/aws4_request, " +
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date, " +
+		"Signature=0004c8d7cfcfa8426088a174c2b50050f72cbdf26010e790573ccb666aa0398f"
+	if got := req.Header.Get("Authorization"); got != wantAuth {
+		t.Errorf("Authorization = %q, want %q", got, wantAuth)
+	}
+
+	wantBodyHash := "14479f4e87d340fe0ca0d522d87a5b3a028ebb1af24fbb8d3ef4553044fc6db6"
+	if got := req.Header.Get("x-amz-content-sha256"); got != wantBodyHash {
+		t.Errorf("x-amz-content-sha256 = %q, want %q", got, wantBodyHash)
+	}
+	if got := req.Header.Get("x-amz-date"); got != "20110909T233600Z" {
+		t.Errorf("x-amz-date = %q, want %q", got, "20110909T233600Z")
+	}
+}
+
+// TestSignAWSRequestV4IncludesSessionToken は、一時クレデンシャルのsessionTokenが渡された場合に
+// x-amz-security-tokenが署名対象ヘッダーへ組み込まれることを確認する
+func TestSignAWSRequestV4IncludesSessionToken(t *testing.T) {
+	body := []byte(`{"anthropic_version":"bedrock-2023-05-31"}`)
+	req, err := http.NewRequest(http.MethodPost, "https://bedrock-runtime.us-west-2.amazonaws.com/model/anthropic.claude-3-5-sonnet-20241022-v2:0/invoke", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	now := time.Date(2011, 9, 9, 23, 36, 0, 0, time.UTC)
+	err = signAWSRequestV4(req, body, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLE", "FQoGZXIvYXdzEXAMPLETOKEN", "us-west-2", "bedrock", now)
+	if err != nil {
+		t.Fatalf("signAWSRequestV4() error = %v", err)
+	}
+
+	wantAuth := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20110909/us-west-2/bedrock/aws4_request, " +
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token, " +
+		"Signature=f86c11a37f085ffb69e7331890e4548b0279c0bc2e2b7e8a7dc0c39a6c8ea6cd"
+	if got := req.Header.Get("Authorization"); got != wantAuth {
+		t.Errorf("Authorization = %q, want %q", got, wantAuth)
+	}
+	if got := req.Header.Get("x-amz-security-token"); got != "FQoGZXIvYXdzEXAMPLETOKEN" {
+		t.Errorf("x-amz-security-token = %q, want %q", got, "FQoGZXIvYXdzEXAMPLETOKEN")
+	}
+}
+
+func TestCanonicalURIEmptyPathIsRoot(t *testing.T) {
+	if got := canonicalURI(""); got != "/" {
+		t.Errorf("canonicalURI(\"\") = %q, want \"/\"", got)
+	}
+	if got := canonicalURI("/model/foo/invoke"); got != "/model/foo/invoke" {
+		t.Errorf("canonicalURI() = %q, want unchanged path", got)
+	}
+}
+
+func TestDeriveSigningKeyIsDeterministic(t *testing.T) {
+	k1 := deriveSigningKey("secret", "20110909", "us-east-1", "bedrock")
+	k2 := deriveSigningKey("secret", "20110909", "us-east-1", "bedrock")
+	if string(k1) != string(k2) {
+		t.Error("deriveSigningKey() is not deterministic for identical inputs")
+	}
+
+	k3 := deriveSigningKey("secret", "20110910", "us-east-1", "bedrock")
+	if string(k1) == string(k3) {
+		t.Error("deriveSigningKey() should differ when the date stamp changes")
+	}
+}
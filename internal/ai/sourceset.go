@@ -0,0 +1,221 @@
+package ai
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const defaultSpecIgnoreFileName = ".specverifyignore"
+
+// binarySniffBytes はバイナリ判定のためにファイル先頭から読み取るバイト数
+const binarySniffBytes = 8000
+
+// errBudgetExceeded はWithMaxTotalBytesで設定したサイズ予算に達したことを表す内部シグナル
+// Walkの呼び出し元には伝播させず、単にそれ以降のファイル探索を打ち切る合図として使う
+var errBudgetExceeded = errors.New("size budget exceeded")
+
+// SourceSet はVerify/VerifyStreamに読み込ませるコード群の集合を表す
+// ディレクトリの再帰走査・個別ファイル・globパターンを好きな順に組み合わせて追加でき、
+// Walkを呼ぶまで実際のファイルシステム探索は行わない（遅延評価）
+type SourceSet struct {
+	additions          []func(fn func(path string) error) error
+	specIgnoreFileName string
+	maxTotalBytes      int64
+	ignoreCache        map[string]*ignoreMatcher
+}
+
+// NewSourceSet は空のSourceSetを作成する
+func NewSourceSet() *SourceSet {
+	return &SourceSet{specIgnoreFileName: defaultSpecIgnoreFileName}
+}
+
+// WithSpecIgnoreFileName は.gitignoreに加えて参照する除外ファイル名を変更する（デフォルトは.specverifyignore）
+func (s *SourceSet) WithSpecIgnoreFileName(name string) *SourceSet {
+	s.specIgnoreFileName = name
+	return s
+}
+
+// WithMaxTotalBytes は読み込むコードの合計バイト数の上限を設定する
+// 上限に達した時点で、それ以降に追加されたエントリの探索は静かに打ち切られる（エラーにはしない）
+func (s *SourceSet) WithMaxTotalBytes(n int64) *SourceSet {
+	s.maxTotalBytes = n
+	return s
+}
+
+// AddDir はrootディレクトリ以下をpattern（ファイル名に対するglobパターン、空文字は全件）で
+// フィルタしながら走査対象に加える。recursiveがfalseの場合はroot直下のみを対象とする
+func (s *SourceSet) AddDir(root, pattern string, recursive bool) {
+	s.additions = append(s.additions, func(fn func(string) error) error {
+		return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if !recursive && path != root {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if pattern != "" {
+				matched, matchErr := filepath.Match(pattern, d.Name())
+				if matchErr != nil {
+					return matchErr
+				}
+				if !matched {
+					return nil
+				}
+			}
+			return fn(path)
+		})
+	})
+}
+
+// AddFile は単一のファイルを走査対象に加える
+func (s *SourceSet) AddFile(path string) {
+	s.additions = append(s.additions, func(fn func(string) error) error {
+		return fn(path)
+	})
+}
+
+// AddGlob はglobパターンに一致するファイルをすべて走査対象に加える
+func (s *SourceSet) AddGlob(pattern string) {
+	s.additions = append(s.additions, func(fn func(string) error) error {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		for _, path := range matches {
+			if err := fn(path); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Walk はこのSourceSetが指す(path, content)の組を、追加した順に1件ずつfnへ渡す
+// .gitignore・指定した除外ファイル・内容がバイナリと判定されたファイルは自動的に読み飛ばす
+// 全件をメモリ上に保持せず、fnが呼ばれるたびにその場でファイルを読み込む
+func (s *SourceSet) Walk(fn func(path, content string) error) error {
+	seen := map[string]bool{}
+	var totalBytes int64
+
+	for _, add := range s.additions {
+		err := add(func(path string) error {
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if seen[absPath] {
+				return nil
+			}
+
+			if s.isIgnored(absPath) {
+				return nil
+			}
+
+			content, isBinary, err := readIfText(path)
+			if err != nil {
+				return err
+			}
+			if isBinary {
+				return nil
+			}
+
+			if s.maxTotalBytes > 0 && totalBytes+int64(len(content)) > s.maxTotalBytes {
+				return errBudgetExceeded
+			}
+			totalBytes += int64(len(content))
+			seen[absPath] = true
+
+			return fn(path, content)
+		})
+
+		if errors.Is(err, errBudgetExceeded) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isIgnored はabsPathの祖先ディレクトリを順に遡りながら.gitignore/specIgnoreFileNameを
+// 読み込み、いずれかのルールに一致するかどうかを判定する
+func (s *SourceSet) isIgnored(absPath string) bool {
+	dir := filepath.Dir(absPath)
+	for {
+		for _, name := range [2]string{".gitignore", s.specIgnoreFileName} {
+			matcher := s.loadIgnoreMatcher(filepath.Join(dir, name))
+			if matcher == nil {
+				continue
+			}
+			rel, err := filepath.Rel(dir, absPath)
+			if err != nil {
+				continue
+			}
+			if matcher.Match(filepath.ToSlash(rel)) {
+				return true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// loadIgnoreMatcher はignoreファイルを読み込み、結果をキャッシュする
+// ルールが1件もない場合（ファイルが存在しない場合を含む）はnilを返し、呼び出し側は
+// 以降のチェックを省略できる
+func (s *SourceSet) loadIgnoreMatcher(path string) *ignoreMatcher {
+	if s.ignoreCache == nil {
+		s.ignoreCache = map[string]*ignoreMatcher{}
+	}
+	if m, ok := s.ignoreCache[path]; ok {
+		return m
+	}
+
+	m, err := loadIgnoreFile(path)
+	if err != nil || len(m.rules) == 0 {
+		s.ignoreCache[path] = nil
+		return nil
+	}
+
+	s.ignoreCache[path] = m
+	return m
+}
+
+// readIfText はpathを読み込み、先頭binarySniffBytesバイトにNUL文字が含まれる場合は
+// バイナリファイルとみなして内容を返さない
+func readIfText(path string) (content string, isBinary bool, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, err
+	}
+
+	sample := data
+	if len(sample) > binarySniffBytes {
+		sample = sample[:binarySniffBytes]
+	}
+	if bytes.IndexByte(sample, 0) != -1 {
+		return "", true, nil
+	}
+
+	return string(data), false, nil
+}
@@ -0,0 +1,60 @@
+package ai
+
+import "context"
+
+// VerificationResult はSPECとコードの一致度検証結果
+type VerificationResult struct {
+	MatchPercentage int      `json:"matchPercentage"`
+	MatchedItems    []string `json:"matchedItems"`
+	UnmatchedItems  []string `json:"unmatchedItems"`
+	Notes           string   `json:"notes"`
+}
+
+// Provider はSPECとコードの検証を行うプロバイダーの共通インターフェース
+type Provider interface {
+	// Name はプロバイダー名を返す
+	Name() string
+	// Verify はSPECとコードの一致度を検証する
+	Verify(ctx context.Context, specContent string, codeContents map[string]string) (*VerificationResult, error)
+}
+
+// VerifyEvent はストリーミング検証の進捗を表す1つのイベント
+// chanを介して逐次配信され、ストリームの終了時にclose()される
+type VerifyEvent struct {
+	// Delta は直前のイベントからの増分テキスト
+	Delta string
+	// MatchedItems は、まだJSON全体が完成していない時点で"matchedItems"配列から
+	// 新たに確定した要素。呼び出し側はDoneを待たずに進捗表示へ反映できる
+	MatchedItems []string
+	// Done はストリームの最後のイベントであることを示す
+	Done bool
+	// Result はDoneがtrueかつErrがnilの場合にのみ設定される最終的な検証結果
+	Result *VerificationResult
+	// Err はストリーム処理中に発生したエラー。Doneを伴うとは限らない
+	Err error
+}
+
+// ProviderConfig は各プロバイダーの生成に必要な設定をまとめたもの
+// プロバイダーごとに使用するフィールドは異なり、不要なフィールドは空のままでよい
+type ProviderConfig struct {
+	// APIKey は認証に使用するAPIキー（Claude/OpenAI/Azure OpenAI共通）
+	APIKey string
+	// Model は使用するモデル名/モデルIDを指定する
+	Model string
+
+	// Endpoint はAzure OpenAIのリソースエンドポイント（例: https://xxx.openai.azure.com）
+	Endpoint string
+	// DeploymentName はAzure OpenAIのデプロイメント名
+	DeploymentName string
+	// APIVersion はAzure OpenAIのAPIバージョン（例: 2024-02-01）
+	APIVersion string
+
+	// Region はAWS Bedrockのリージョン（例: us-east-1）
+	Region string
+	// AccessKeyID はAWS Bedrockの認証情報
+	AccessKeyID string
+	// SecretAccessKey はAWS Bedrockの認証情報
+	SecretAccessKey string
+	// SessionToken はAWS Bedrockの一時認証情報（STS利用時のみ）
+	SessionToken string
+}